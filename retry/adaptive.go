@@ -0,0 +1,83 @@
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// AdaptiveStrategy is a Strategy that adjusts its interval based on observed
+// success/failure feedback (shrink by 2^decay on success, grow by 2^attack
+// on failure)
+// rather than purely on attempt count. Install it with WithAdaptiveStrategy
+// so the Retrier's MarkSuccess/MarkFailure can reach it.
+type AdaptiveStrategy struct {
+	mu       sync.Mutex
+	interval time.Duration
+
+	min, max      time.Duration
+	decay, attack uint
+}
+
+// maxShift bounds decay/attack so that 1<<n can never overflow time.Duration
+// (an int64) down to 0. Left unbounded, a decay of 64 or more would turn
+// markSuccess's halving into a divide-by-zero panic.
+const maxShift = 62
+
+// Adaptive returns an AdaptiveStrategy that starts at min and moves toward
+// max on failure or back toward min on success. On failure the interval is
+// multiplied by 2^attack, capped at max; on success it's divided by 2^decay,
+// floored at min. decay and attack are clamped to maxShift. Share one
+// Retrier configured with Adaptive across concurrent requests to a flaky
+// backend and let it converge on the backend's sustainable rate, rather than
+// backing off purely on attempt count the way Exponential does.
+func Adaptive(min, max time.Duration, decay, attack uint) *AdaptiveStrategy {
+	if decay > maxShift {
+		decay = maxShift
+	}
+	if attack > maxShift {
+		attack = maxShift
+	}
+
+	return &AdaptiveStrategy{
+		interval: min,
+		min:      min,
+		max:      max,
+		decay:    decay,
+		attack:   attack,
+	}
+}
+
+func (a *AdaptiveStrategy) next(uint) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.interval
+}
+
+func (a *AdaptiveStrategy) markSuccess() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.interval /= 1 << a.decay
+	if a.interval < a.min {
+		a.interval = a.min
+	}
+}
+
+func (a *AdaptiveStrategy) markFailure() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.interval *= 1 << a.attack
+	if a.interval <= 0 || a.interval > a.max {
+		a.interval = a.max
+	}
+}
+
+// WithAdaptiveStrategy installs an AdaptiveStrategy as the Retrier's
+// Strategy and wires it up to receive feedback from MarkSuccess/MarkFailure.
+// Plain Strategy funcs (Constant, Exponential) ignore that feedback; use
+// WithStrategy for those.
+func WithAdaptiveStrategy(a *AdaptiveStrategy) Option {
+	return func(r *Retrier) {
+		r.strategy = a.next
+		r.adaptive = a
+	}
+}
@@ -0,0 +1,48 @@
+package retry
+
+import "time"
+
+// Clock abstracts time so Retrier's waits can be driven virtually in tests
+// instead of accumulating real time.Sleep durations. See the retrytest
+// subpackage for a test double.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a Timer that fires after d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the subset of time.Timer that Clock implementations need to
+// provide.
+type Timer interface {
+	// C returns the channel on which the time is sent when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, returning true if it stopped it,
+	// false if it had already fired or been stopped.
+	Stop() bool
+}
+
+// realClock implements Clock using the time package directly.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (r realTimer) C() <-chan time.Time { return r.t.C }
+func (r realTimer) Stop() bool          { return r.t.Stop() }
+
+// WithClock overrides the Clock used for Elapsed/Start bookkeeping and for
+// the waits performed by Do/DoWithData. It defaults to the real system
+// clock; tests should install a retrytest.FakeClock instead.
+func WithClock(c Clock) Option {
+	return func(r *Retrier) {
+		r.clock = c
+	}
+}
@@ -0,0 +1,145 @@
+package retry
+
+import (
+	"context"
+	"errors"
+)
+
+// WithRetryIf restricts retries to errors for which fn returns true. Errors
+// for which fn returns false are returned immediately without consuming
+// another attempt. If unset, every error is retried.
+func WithRetryIf(fn func(error) bool) Option {
+	return func(r *Retrier) {
+		r.retryIf = fn
+	}
+}
+
+// WithAbortIf stops the retry loop immediately, without consuming another
+// attempt, whenever fn returns true for the most recent error. It behaves
+// as if Break had been called from inside the loop.
+func WithAbortIf(fn func(error) bool) Option {
+	return func(r *Retrier) {
+		r.abortIf = fn
+	}
+}
+
+// WithOnRetry registers a callback invoked after each failed attempt, before
+// the retrier sleeps for the next interval. attempt is the 1-based count of
+// the attempt that just failed.
+func WithOnRetry(fn func(attempt uint, err error)) Option {
+	return func(r *Retrier) {
+		r.onRetry = fn
+	}
+}
+
+// unrecoverableError marks an error as terminal for Do/DoWithData.
+type unrecoverableError struct {
+	err error
+}
+
+func (u *unrecoverableError) Error() string { return u.err.Error() }
+func (u *unrecoverableError) Unwrap() error { return u.err }
+
+// Unrecoverable wraps err so that Do and DoWithData stop retrying as soon as
+// they see it, returning the underlying error rather than retrying further.
+func Unrecoverable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &unrecoverableError{err: err}
+}
+
+// Do repeatedly calls fn until it succeeds, the Retrier gives up, or ctx is
+// canceled. Between attempts it sleeps for NextInterval, returning early if
+// ctx is done first. fn has no way to observe a per-attempt deadline, so
+// WithPerAttemptTimeout has no effect here; use DoCtx if fn needs to react
+// to one.
+func (r *Retrier) Do(ctx context.Context, fn func() error) error {
+	_, err := DoWithData(ctx, r, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// DoWithData is Do for functions that also produce a value on success.
+func DoWithData[T any](ctx context.Context, r *Retrier, fn func() (T, error)) (T, error) {
+	return doLoop(ctx, r, func(context.Context) (T, error) {
+		return fn()
+	})
+}
+
+// DoCtx is Do for functions that want their own derived context, so that
+// WithPerAttemptTimeout can cancel a slow attempt. DoCtx still waits for fn
+// to return; an fn that ignores its context is not cut short.
+func (r *Retrier) DoCtx(ctx context.Context, fn func(context.Context) error) error {
+	_, err := DoWithDataCtx(ctx, r, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// DoWithDataCtx is DoCtx for functions that also produce a value on success.
+func DoWithDataCtx[T any](ctx context.Context, r *Retrier, fn func(context.Context) (T, error)) (T, error) {
+	return doLoop(ctx, r, fn)
+}
+
+// doLoop holds the retry/backoff logic shared by the ctx-oblivious and
+// ctx-aware Do variants. invoke is called with a per-attempt context
+// (bounded by WithPerAttemptTimeout, if set).
+func doLoop[T any](ctx context.Context, r *Retrier, invoke func(context.Context) (T, error)) (T, error) {
+	var zero T
+	var lastErr error
+
+	r.Start()
+
+	for {
+		r.MarkAttempt()
+		if r.ShouldGiveUp() {
+			return zero, lastErr
+		}
+
+		attemptCtx := ctx
+		var cancel context.CancelFunc
+		if r.perAttemptTimeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, r.perAttemptTimeout)
+		}
+
+		value, err := invoke(attemptCtx)
+		if cancel != nil {
+			cancel()
+		}
+
+		if err == nil {
+			r.MarkSuccess()
+			return value, nil
+		}
+		r.MarkFailure()
+		lastErr = err
+
+		var unrecoverable *unrecoverableError
+		if errors.As(err, &unrecoverable) {
+			return zero, unrecoverable.err
+		}
+
+		if r.abortIf != nil && r.abortIf(err) {
+			r.Break()
+			return zero, err
+		}
+
+		if r.retryIf != nil && !r.retryIf(err) {
+			return zero, err
+		}
+
+		if r.onRetry != nil {
+			r.onRetry(r.Attempts(), err)
+		}
+
+		timer := r.clock.NewTimer(r.NextInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, errors.Join(err, ctx.Err())
+		case <-timer.C():
+		}
+	}
+}
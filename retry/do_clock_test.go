@@ -0,0 +1,102 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NirvanaNimbusa/agent/retry"
+	"github.com/NirvanaNimbusa/agent/retry/retrytest"
+)
+
+// These tests live in an external retry_test package, rather than retry's
+// own internal test file, because retrytest imports retry to implement
+// Clock/Timer: an internal test file importing retrytest back would be an
+// import cycle.
+
+func TestElapsed_UsesInjectedClock(t *testing.T) {
+	clock := retrytest.NewFakeClock(time.Unix(0, 0))
+	r := retry.NewRetrier(
+		retry.WithStrategy(retry.Constant(time.Millisecond)),
+		retry.WithClock(clock),
+	)
+
+	r.Start()
+	clock.Advance(5 * time.Second)
+
+	assert.Equal(t, 5*time.Second, r.Elapsed())
+}
+
+func TestShouldGiveUp_MaxElapsedTimeWithInjectedClock(t *testing.T) {
+	clock := retrytest.NewFakeClock(time.Unix(0, 0))
+	r := retry.NewRetrier(
+		retry.WithStrategy(retry.Constant(time.Millisecond)),
+		retry.WithMaxAttempts(1_000_000),
+		retry.WithMaxElapsedTime(10*time.Second),
+		retry.WithClock(clock),
+	)
+
+	r.MarkAttempt()
+	assert.False(t, r.ShouldGiveUp())
+
+	clock.Advance(15 * time.Second)
+	assert.True(t, r.ShouldGiveUp())
+}
+
+// TestDo_SleepsAreDrivenByFakeClock drives Do through two real sleeps of an
+// hour each, proving the sleep-between-attempts path goes through the
+// injected Clock rather than time.Sleep: the test only ever blocks on short,
+// bounded polls of the fake clock's pending timers, never on the hour itself.
+func TestDo_SleepsAreDrivenByFakeClock(t *testing.T) {
+	clock := retrytest.NewFakeClock(time.Unix(0, 0))
+	r := retry.NewRetrier(
+		retry.WithStrategy(retry.Constant(time.Hour)),
+		retry.WithMaxAttempts(3),
+		retry.WithClock(clock),
+	)
+
+	attempts := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Do(context.Background(), func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		})
+	}()
+
+	waitForPendingTimer(t, clock)
+	clock.Advance(time.Hour)
+
+	waitForPendingTimer(t, clock)
+	clock.Advance(time.Hour)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Do did not return after the fake clock was advanced past its sleeps")
+	}
+
+	assert.Equal(t, 3, attempts)
+}
+
+// waitForPendingTimer polls clock for a timer Do is blocked on, bounded by a
+// short real-time deadline so a regression fails fast instead of hanging.
+func waitForPendingTimer(t *testing.T, clock *retrytest.FakeClock) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if clock.PendingTimers() > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for Do to start its sleep")
+}
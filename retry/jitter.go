@@ -0,0 +1,106 @@
+package retry
+
+import (
+	"math/rand"
+	"time"
+)
+
+// JitterMode selects how NextInterval perturbs the strategy's computed
+// interval to avoid many retriers waking up in lockstep.
+type JitterMode int
+
+const (
+	// JitterNone applies no jitter; NextInterval returns the strategy's
+	// value unchanged. This is the default.
+	JitterNone JitterMode = iota
+	// JitterFull returns a uniform random duration in [0, base).
+	JitterFull
+	// JitterEqual returns base/2 plus a uniform random duration in
+	// [0, base/2), so the interval never drops below half of base.
+	JitterEqual
+	// JitterDecorrelated grows the interval from the previous sleep rather
+	// than from the attempt count alone: next = min(ceiling, rand(floor,
+	// prev*3)), where floor is the strategy's minimum (its value for the
+	// first attempt) and ceiling is the strategy's value for the current
+	// attempt. It requires state (the previous sleep) tracked on the
+	// Retrier across calls.
+	JitterDecorrelated
+)
+
+// jitterInterval bounds the legacy uniform jitter applied by
+// WithLegacyJitter: the returned interval is always within this duration of
+// the strategy's value.
+const jitterInterval = 1 * time.Second
+
+// WithJitter perturbs each computed interval according to mode, to avoid
+// many retriers waking up in lockstep. See the JitterMode constants for the
+// available strategies.
+func WithJitter(mode JitterMode) Option {
+	return func(r *Retrier) {
+		r.jitterMode = mode
+	}
+}
+
+// WithLegacyJitter restores the original WithJitter behavior: a fixed ±1s
+// band around the strategy's value, regardless of how large or small that
+// value is.
+//
+// Deprecated: use WithJitter(JitterFull) or WithJitter(JitterEqual) instead;
+// they scale with the interval rather than distorting small ones and barely
+// perturbing long ones.
+func WithLegacyJitter() Option {
+	return func(r *Retrier) {
+		r.jitter = true
+	}
+}
+
+func applyLegacyJitter(d time.Duration) time.Duration {
+	delta := time.Duration(rand.Int63n(int64(2*jitterInterval))) - jitterInterval
+	jittered := d + delta
+	if jittered < 0 {
+		return 0
+	}
+	return jittered
+}
+
+func applyJitterMode(mode JitterMode, base time.Duration, decorrelated func(time.Duration) time.Duration) time.Duration {
+	switch mode {
+	case JitterFull:
+		return randDuration(0, base)
+	case JitterEqual:
+		return base/2 + randDuration(0, base/2)
+	case JitterDecorrelated:
+		return decorrelated(base)
+	default:
+		return base
+	}
+}
+
+// randDuration returns a uniform random duration in [lo, hi), or lo if
+// hi <= lo.
+func randDuration(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + time.Duration(rand.Int63n(int64(hi-lo)))
+}
+
+// decorrelatedJitterLocked implements the JitterDecorrelated formula,
+// persisting the chosen sleep on the Retrier so the next call can grow from
+// it. Callers must hold r.mu.
+func (r *Retrier) decorrelatedJitterLocked(ceiling time.Duration) time.Duration {
+	floor := r.strategy(0)
+
+	prev := r.lastSleep
+	if prev <= 0 {
+		prev = floor
+	}
+
+	candidate := randDuration(floor, prev*3)
+	if candidate > ceiling {
+		candidate = ceiling
+	}
+
+	r.lastSleep = candidate
+	return candidate
+}
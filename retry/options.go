@@ -0,0 +1,52 @@
+package retry
+
+import "time"
+
+// Option configures a Retrier.
+type Option func(*Retrier)
+
+// WithStrategy sets the backoff strategy used to compute intervals between
+// attempts.
+func WithStrategy(s Strategy) Option {
+	return func(r *Retrier) {
+		r.strategy = s
+	}
+}
+
+// WithMaxAttempts caps the number of attempts the retrier will allow before
+// ShouldGiveUp returns true.
+func WithMaxAttempts(n uint) Option {
+	return func(r *Retrier) {
+		r.maxAttempts = n
+		r.forever = false
+	}
+}
+
+// TryForever disables the max-attempts check, so ShouldGiveUp only returns
+// true if Break is called.
+func TryForever() Option {
+	return func(r *Retrier) {
+		r.forever = true
+	}
+}
+
+// WithMaxElapsedTime caps the total time since the clock was started (see
+// Retrier.Start) that the retrier will allow before ShouldGiveUp returns
+// true, regardless of WithMaxAttempts or TryForever. It saves callers from
+// deriving a max-attempts count from the strategy by hand when what they
+// actually want is "retry for at most d".
+func WithMaxElapsedTime(d time.Duration) Option {
+	return func(r *Retrier) {
+		r.maxElapsedTime = d
+	}
+}
+
+// WithPerAttemptTimeout gives each individual attempt a context.WithTimeout
+// of d, independent of WithMaxElapsedTime's budget for the whole operation.
+// Only DoCtx and DoWithDataCtx pass that context to fn; Do and DoWithData
+// call fn without it, so the timeout has no effect on them.
+func WithPerAttemptTimeout(d time.Duration) Option {
+	return func(r *Retrier) {
+		r.perAttemptTimeout = d
+	}
+}
@@ -0,0 +1,182 @@
+// Package retry provides a small, composable backoff/retry helper.
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// Retrier tracks retry attempt state and computes the interval to wait
+// before the next attempt, based on a pluggable Strategy. The zero value is
+// not usable; construct one with NewRetrier. A *Retrier is safe for
+// concurrent use, so one instance can be shared across goroutines (e.g. by
+// retryhttp.Transport, or an Adaptive strategy pacing concurrent callers).
+type Retrier struct {
+	strategy          Strategy
+	maxAttempts       uint
+	forever           bool
+	jitter            bool
+	jitterMode        JitterMode
+	maxElapsedTime    time.Duration
+	perAttemptTimeout time.Duration
+	clock             Clock
+	retryAfterPolicy  RetryAfterPolicy
+
+	retryIf  func(error) bool
+	abortIf  func(error) bool
+	onRetry  func(attempt uint, err error)
+	adaptive *AdaptiveStrategy
+
+	mu             sync.Mutex
+	attempts       uint
+	broken         bool
+	lastSleep      time.Duration
+	startTime      time.Time
+	retryAfterHint time.Duration
+	retryAfterSet  bool
+}
+
+// NewRetrier constructs a Retrier from the given options. Callers should
+// always supply WithStrategy and one of WithMaxAttempts or TryForever.
+func NewRetrier(opts ...Option) *Retrier {
+	r := &Retrier{
+		strategy:    Constant(0),
+		maxAttempts: 1,
+		clock:       realClock{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// MarkAttempt records that an attempt has been made, starting the elapsed-
+// time clock (see Start) if it hasn't been started already.
+func (r *Retrier) MarkAttempt() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.startTime.IsZero() {
+		r.startTime = r.clock.Now()
+	}
+	r.attempts++
+}
+
+// Attempts returns the number of attempts marked so far.
+func (r *Retrier) Attempts() uint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts
+}
+
+// Start resets the Retrier for a new operation: it clears the attempt count
+// and the broken flag, and (re)starts the elapsed-time clock used by
+// WithMaxElapsedTime and Elapsed. Do/DoWithData call it automatically at the
+// start of every call, so a Retrier built for reuse across operations — e.g.
+// retryhttp.Transport's "shared across all requests" Retrier, or an
+// AdaptiveStrategy pacing concurrent callers — starts each one fresh rather
+// than inheriting attempts, or a Break, left over from whichever operation
+// used it last. Strategy state such as an AdaptiveStrategy's interval is
+// untouched, since that's meant to persist across operations. Manual-loop
+// callers only need to call Start themselves to measure elapsed time before
+// their first attempt.
+func (r *Retrier) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = 0
+	r.broken = false
+	r.startTime = r.clock.Now()
+}
+
+// Elapsed returns the time since the clock was started (see Start), or zero
+// if it hasn't been started yet.
+func (r *Retrier) Elapsed() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.elapsedLocked()
+}
+
+func (r *Retrier) elapsedLocked() time.Duration {
+	if r.startTime.IsZero() {
+		return 0
+	}
+	return r.clock.Now().Sub(r.startTime)
+}
+
+// ShouldGiveUp reports whether the retrier has exhausted its attempts or
+// elapsed-time budget, been stopped via Break, or otherwise determined that
+// no further attempts should be made.
+func (r *Retrier) ShouldGiveUp() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.broken {
+		return true
+	}
+	if r.maxElapsedTime > 0 && r.elapsedLocked() >= r.maxElapsedTime {
+		return true
+	}
+	if r.forever {
+		return false
+	}
+	return r.attempts > r.maxAttempts
+}
+
+// NextInterval returns the duration to wait before the next attempt, based
+// on the number of attempts made so far. A hint set via
+// SetNextIntervalHint is combined in according to the configured
+// RetryAfterPolicy and then cleared.
+func (r *Retrier) NextInterval() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interval := r.strategy(r.attempts)
+	if r.jitter {
+		interval = applyLegacyJitter(interval)
+	} else {
+		interval = applyJitterMode(r.jitterMode, interval, r.decorrelatedJitterLocked)
+	}
+
+	if r.retryAfterSet {
+		switch r.retryAfterPolicy {
+		case PolicyReplace:
+			interval = r.retryAfterHint
+		default:
+			if r.retryAfterHint > interval {
+				interval = r.retryAfterHint
+			}
+		}
+		r.retryAfterSet = false
+	}
+
+	return interval
+}
+
+// Break forces ShouldGiveUp to return true, regardless of the configured
+// strategy or attempt count. It's typically used when an error is
+// determined to be unrecoverable.
+func (r *Retrier) Break() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.broken = true
+}
+
+// MarkSuccess notifies an AdaptiveStrategy (configured via
+// WithAdaptiveStrategy) that the last attempt succeeded, shrinking its
+// interval. It's a no-op otherwise.
+func (r *Retrier) MarkSuccess() {
+	if r.adaptive != nil {
+		r.adaptive.markSuccess()
+	}
+}
+
+// MarkFailure notifies an AdaptiveStrategy (configured via
+// WithAdaptiveStrategy) that the last attempt failed, growing its interval.
+// It's a no-op otherwise.
+func (r *Retrier) MarkFailure() {
+	if r.adaptive != nil {
+		r.adaptive.markFailure()
+	}
+}
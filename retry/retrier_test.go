@@ -1,6 +1,8 @@
 package retry
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -69,7 +71,7 @@ func TestNextInterval_ConstantStrategy_WithJitter(t *testing.T) {
 	expected := 5 * time.Second
 	r := NewRetrier(
 		WithStrategy(Constant(expected)),
-		WithJitter(),
+		WithLegacyJitter(),
 		WithMaxAttempts(1000),
 	)
 
@@ -146,6 +148,332 @@ func TestNextInterval_ExponentialStrategy_WithJitter(t *testing.T) {
 	}
 }
 
+func TestDo_SucceedsEventually(t *testing.T) {
+	attempts := 0
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(5),
+	)
+
+	err := r.Do(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDo_ReusedRetrierResetsPerOperation(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(3),
+	)
+
+	for i := 0; i < 5; i++ {
+		calls := 0
+		err := r.Do(context.Background(), func() error {
+			calls++
+			if calls < 2 {
+				return errors.New("not yet")
+			}
+			return nil
+		})
+		assert.NoError(t, err, "call %d", i)
+	}
+}
+
+func TestDo_GivesUp(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(2),
+	)
+
+	err := r.Do(context.Background(), func() error {
+		return errors.New("always fails")
+	})
+
+	assert.EqualError(t, err, "always fails")
+}
+
+func TestDo_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := NewRetrier(
+		WithStrategy(Constant(time.Hour)),
+		TryForever(),
+	)
+
+	err := r.Do(ctx, func() error {
+		return errors.New("boom")
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDoWithData_ReturnsValue(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(3),
+	)
+
+	value, err := DoWithData(context.Background(), r, func() (int, error) {
+		return 42, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, value)
+}
+
+func TestDo_Unrecoverable(t *testing.T) {
+	attempts := 0
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(10),
+	)
+
+	err := r.Do(context.Background(), func() error {
+		attempts++
+		return Unrecoverable(errors.New("fatal"))
+	})
+
+	assert.EqualError(t, err, "fatal")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDo_RetryIf(t *testing.T) {
+	transient := errors.New("transient")
+	attempts := 0
+
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(5),
+		WithRetryIf(func(err error) bool {
+			return errors.Is(err, transient)
+		}),
+	)
+
+	err := r.Do(context.Background(), func() error {
+		attempts++
+		return errors.New("permanent")
+	})
+
+	assert.EqualError(t, err, "permanent")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDo_AbortIf(t *testing.T) {
+	attempts := 0
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(10),
+		WithAbortIf(func(err error) bool {
+			return err.Error() == "stop"
+		}),
+	)
+
+	err := r.Do(context.Background(), func() error {
+		attempts++
+		return errors.New("stop")
+	})
+
+	assert.EqualError(t, err, "stop")
+	assert.True(t, r.ShouldGiveUp())
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDo_FeedsAdaptiveStrategy(t *testing.T) {
+	a := Adaptive(1*time.Millisecond, 8*time.Millisecond, 1, 1)
+	r := NewRetrier(
+		WithAdaptiveStrategy(a),
+		WithMaxAttempts(5),
+	)
+
+	attempts := 0
+	err := r.Do(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Millisecond, r.NextInterval())
+}
+
+func TestDo_OnRetry(t *testing.T) {
+	var seen []uint
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(3),
+		WithOnRetry(func(attempt uint, err error) {
+			seen = append(seen, attempt)
+		}),
+	)
+
+	_ = r.Do(context.Background(), func() error {
+		return errors.New("nope")
+	})
+
+	assert.Equal(t, []uint{1, 2, 3}, seen)
+}
+
+func TestAdaptiveStrategy_BacksOffOnFailureAndRecoversOnSuccess(t *testing.T) {
+	a := Adaptive(1*time.Second, 16*time.Second, 1, 1)
+	r := NewRetrier(
+		WithAdaptiveStrategy(a),
+		WithMaxAttempts(1000),
+	)
+
+	assert.Equal(t, 1*time.Second, r.NextInterval())
+
+	r.MarkFailure()
+	assert.Equal(t, 2*time.Second, r.NextInterval())
+
+	r.MarkFailure()
+	assert.Equal(t, 4*time.Second, r.NextInterval())
+
+	r.MarkSuccess()
+	assert.Equal(t, 2*time.Second, r.NextInterval())
+}
+
+func TestAdaptiveStrategy_ClampsToMinAndMax(t *testing.T) {
+	a := Adaptive(1*time.Second, 4*time.Second, 1, 3)
+	r := NewRetrier(
+		WithAdaptiveStrategy(a),
+		WithMaxAttempts(1000),
+	)
+
+	r.MarkFailure()
+	assert.Equal(t, 4*time.Second, r.NextInterval())
+
+	r.MarkSuccess()
+	r.MarkSuccess()
+	r.MarkSuccess()
+	assert.Equal(t, 1*time.Second, r.NextInterval())
+}
+
+func TestAdaptiveStrategy_UnaffectedByMarkSuccessFailureWithoutIt(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(1*time.Second)),
+		WithMaxAttempts(1000),
+	)
+
+	r.MarkSuccess()
+	r.MarkFailure()
+
+	assert.Equal(t, 1*time.Second, r.NextInterval())
+}
+
+func TestNextInterval_JitterFull(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(10*time.Second)),
+		WithJitter(JitterFull),
+		WithMaxAttempts(1000),
+	)
+
+	for _, interval := range generateIntervals(r, 50) {
+		assert.True(t, interval >= 0 && interval < 10*time.Second, "interval %v out of [0, 10s)", interval)
+	}
+}
+
+func TestNextInterval_JitterEqual(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(10*time.Second)),
+		WithJitter(JitterEqual),
+		WithMaxAttempts(1000),
+	)
+
+	for _, interval := range generateIntervals(r, 50) {
+		assert.True(t, interval >= 5*time.Second && interval < 10*time.Second, "interval %v out of [5s, 10s)", interval)
+	}
+}
+
+func TestNextInterval_JitterDecorrelated(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Exponential(2*time.Second, 0)),
+		WithJitter(JitterDecorrelated),
+		WithMaxAttempts(1000),
+	)
+
+	strategy := Exponential(2*time.Second, 0)
+	for attempt := 0; attempt < 10; attempt++ {
+		interval := r.NextInterval()
+		ceiling := strategy(uint(attempt))
+		assert.True(t, interval >= 0 && interval <= ceiling, "interval %v exceeded ceiling %v", interval, ceiling)
+		r.MarkAttempt()
+	}
+}
+
+func TestShouldGiveUp_MaxElapsedTime(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(1_000_000),
+		WithMaxElapsedTime(10*time.Millisecond),
+	)
+
+	r.MarkAttempt()
+	assert.False(t, r.ShouldGiveUp())
+
+	time.Sleep(15 * time.Millisecond)
+	assert.True(t, r.ShouldGiveUp())
+}
+
+func TestElapsed_ZeroBeforeStart(t *testing.T) {
+	r := NewRetrier(WithStrategy(Constant(time.Millisecond)))
+	assert.Equal(t, time.Duration(0), r.Elapsed())
+}
+
+func TestDo_PerAttemptTimeout(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(time.Millisecond)),
+		WithMaxAttempts(3),
+		WithPerAttemptTimeout(5*time.Millisecond),
+	)
+
+	err := r.DoCtx(context.Background(), func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.True(t, time.Until(deadline) <= 5*time.Millisecond)
+		return errors.New("always fails")
+	})
+
+	assert.EqualError(t, err, "always fails")
+}
+
+func TestNextInterval_RetryAfterHint_PolicyMax(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(5*time.Second)),
+		WithMaxAttempts(1000),
+	)
+
+	r.SetNextIntervalHint(2 * time.Second)
+	assert.Equal(t, 5*time.Second, r.NextInterval(), "hint smaller than strategy should be ignored under PolicyMax")
+
+	r.SetNextIntervalHint(30 * time.Second)
+	assert.Equal(t, 30*time.Second, r.NextInterval(), "hint larger than strategy should win under PolicyMax")
+
+	// the hint is cleared after being consumed once
+	assert.Equal(t, 5*time.Second, r.NextInterval())
+}
+
+func TestNextInterval_RetryAfterHint_PolicyReplace(t *testing.T) {
+	r := NewRetrier(
+		WithStrategy(Constant(5*time.Second)),
+		WithMaxAttempts(1000),
+		WithRetryAfterPolicy(PolicyReplace),
+	)
+
+	r.SetNextIntervalHint(2 * time.Second)
+	assert.Equal(t, 2*time.Second, r.NextInterval())
+	assert.Equal(t, 5*time.Second, r.NextInterval())
+}
+
 func generateIntervals(retrier *Retrier, howMany int) []time.Duration {
 	actualIntervals := make([]time.Duration, 0, howMany)
 	for i := 0; i < 5; i++ {
@@ -0,0 +1,38 @@
+package retry
+
+import "time"
+
+// RetryAfterPolicy controls how a hint set via SetNextIntervalHint combines
+// with the strategy's own computed interval.
+type RetryAfterPolicy int
+
+const (
+	// PolicyMax takes the larger of the hint and the strategy's interval.
+	// This is the default: it never sleeps less than the strategy wants,
+	// even if a server sends a surprisingly short Retry-After.
+	PolicyMax RetryAfterPolicy = iota
+	// PolicyReplace uses the hint verbatim, ignoring the strategy's
+	// interval for that attempt.
+	PolicyReplace
+)
+
+// WithRetryAfterPolicy sets how a hint from SetNextIntervalHint is combined
+// with the strategy's interval. It defaults to PolicyMax.
+func WithRetryAfterPolicy(p RetryAfterPolicy) Option {
+	return func(r *Retrier) {
+		r.retryAfterPolicy = p
+	}
+}
+
+// SetNextIntervalHint supplies a hint for the interval returned by the next
+// NextInterval call only, combined with the strategy's interval according to
+// the configured RetryAfterPolicy. It's meant for callers who parsed a
+// Retry-After header, a gRPC RetryInfo message, or a 429 body, and want the
+// retrier to honor the server's requested delay. The hint is cleared after
+// the next NextInterval call, whether or not it ended up being used.
+func (r *Retrier) SetNextIntervalHint(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retryAfterHint = d
+	r.retryAfterSet = true
+}
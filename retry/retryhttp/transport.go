@@ -0,0 +1,100 @@
+// Package retryhttp turns a *retry.Retrier into a drop-in retrying
+// http.RoundTripper, the dominant use case for a backoff library.
+package retryhttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NirvanaNimbusa/agent/retry"
+)
+
+// Transport wraps Base (http.DefaultTransport if nil), retrying requests
+// through Retrier. It retries on 5xx and 429 responses and on timeouts
+// reported by the underlying net.Error, feeding any Retry-After header it
+// sees back into the Retrier via SetNextIntervalHint.
+//
+// Retrier is shared across all requests made through the Transport, so its
+// strategy (e.g. Adaptive) can pace the whole client rather than just one
+// request.
+type Transport struct {
+	Base    http.RoundTripper
+	Retrier *retry.Retrier
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return retry.DoWithDataCtx(req.Context(), t.Retrier, func(ctx context.Context) (*http.Response, error) {
+		attemptReq := req.WithContext(ctx)
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.base().RoundTrip(attemptReq)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return nil, err
+			}
+			return nil, retry.Unrecoverable(err)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			if hint, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				t.Retrier.SetNextIntervalHint(hint)
+			}
+			resp.Body.Close()
+			return nil, fmt.Errorf("retryhttp: received status %d", resp.StatusCode)
+		}
+
+		return resp, nil
+	})
+}
+
+// parseRetryAfter parses a Retry-After header value in either its
+// delta-seconds or HTTP-date form.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
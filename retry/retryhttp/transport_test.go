@@ -0,0 +1,99 @@
+package retryhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NirvanaNimbusa/agent/retry"
+)
+
+func TestTransport_RetriesOnServiceUnavailableWithRetryAfter(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &Transport{
+			Retrier: retry.NewRetrier(
+				retry.WithStrategy(retry.Constant(time.Millisecond)),
+				retry.WithMaxAttempts(3),
+			),
+		},
+	}
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, requests)
+}
+
+func TestTransport_GivesUpAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &Transport{
+			Retrier: retry.NewRetrier(
+				retry.WithStrategy(retry.Constant(time.Millisecond)),
+				retry.WithMaxAttempts(2),
+			),
+		},
+	}
+
+	_, err := client.Get(server.URL)
+	assert.Error(t, err)
+}
+
+func TestTransport_DoesNotRetryNonRetryableStatus(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &Transport{
+			Retrier: retry.NewRetrier(
+				retry.WithStrategy(retry.Constant(time.Millisecond)),
+				retry.WithMaxAttempts(5),
+			),
+		},
+	}
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, 1, requests)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 120*time.Second, d)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("-5")
+	assert.False(t, ok)
+
+	future := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	d, ok = parseRetryAfter(future)
+	assert.True(t, ok)
+	assert.True(t, d > 0 && d <= 30*time.Second)
+}
@@ -0,0 +1,104 @@
+// Package retrytest provides test doubles for the retry package's Clock
+// interface, so tests of retry behavior finish in microseconds instead of
+// accumulating real time.Sleep durations.
+package retrytest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NirvanaNimbusa/agent/retry"
+)
+
+// FakeClock is a retry.Clock whose Now and timers only move when Advance is
+// called.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock returns a FakeClock starting at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the clock's current virtual time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a retry.Timer that fires once the clock has been
+// Advanced by at least d from the time NewTimer was called.
+func (c *FakeClock) NewTimer(d time.Duration) retry.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{
+		clock:    c,
+		deadline: c.now.Add(d),
+		ch:       make(chan time.Time, 1),
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// PendingTimers returns the number of timers that have been created but not
+// yet fired or stopped. Tests that run Do/DoWithData in a goroutine can poll
+// this to know when it's safe to Advance past the sleep it's waiting on.
+func (c *FakeClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := 0
+	for _, t := range c.timers {
+		if !t.stopped {
+			pending++
+		}
+	}
+	return pending
+}
+
+// Advance moves the clock forward by d, firing any pending timers whose
+// deadline has been reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	remaining := c.timers[:0]
+	for _, t := range c.timers {
+		if t.stopped {
+			continue
+		}
+		if t.deadline.After(c.now) {
+			remaining = append(remaining, t)
+			continue
+		}
+		t.fired = true
+		t.ch <- c.now
+	}
+	c.timers = remaining
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	ch       chan time.Time
+	stopped  bool
+	fired    bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasRunning := !t.stopped && !t.fired
+	t.stopped = true
+	return wasRunning
+}
@@ -0,0 +1,53 @@
+package retrytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock_NowAdvances(t *testing.T) {
+	start := time.Unix(1000, 0)
+	c := NewFakeClock(start)
+
+	assert.Equal(t, start, c.Now())
+
+	c.Advance(5 * time.Second)
+	assert.Equal(t, start.Add(5*time.Second), c.Now())
+}
+
+func TestFakeClock_TimerFiresOnAdvance(t *testing.T) {
+	c := NewFakeClock(time.Unix(0, 0))
+	timer := c.NewTimer(10 * time.Second)
+
+	c.Advance(5 * time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	c.Advance(5 * time.Second)
+	select {
+	case fired := <-timer.C():
+		assert.Equal(t, c.Now(), fired)
+	default:
+		t.Fatal("timer did not fire once its deadline was reached")
+	}
+}
+
+func TestFakeClock_StopPreventsFiring(t *testing.T) {
+	c := NewFakeClock(time.Unix(0, 0))
+	timer := c.NewTimer(time.Second)
+
+	assert.True(t, timer.Stop())
+	assert.False(t, timer.Stop())
+
+	c.Advance(time.Minute)
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+}
@@ -0,0 +1,24 @@
+package retry
+
+import "time"
+
+// Strategy computes the interval to wait before the next attempt, given the
+// number of attempts already made. Do and DoWithData mark each attempt
+// before making it, so they pass 1 for the wait before the first retry.
+type Strategy func(attempts uint) time.Duration
+
+// Constant returns a Strategy that always waits the same interval.
+func Constant(d time.Duration) Strategy {
+	return func(uint) time.Duration {
+		return d
+	}
+}
+
+// Exponential returns a Strategy that doubles the wait on every attempt,
+// starting at base/2 and adding a constant adjustment to every interval.
+// For example, Exponential(2*time.Second, 0) yields 1s, 2s, 4s, 8s, ...
+func Exponential(base, adjustment time.Duration) Strategy {
+	return func(attempts uint) time.Duration {
+		return (base/2)*time.Duration(uint64(1)<<attempts) + adjustment
+	}
+}